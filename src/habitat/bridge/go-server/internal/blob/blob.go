@@ -0,0 +1,39 @@
+// Package blob provides a pluggable storage abstraction used by the bridge
+// to move large file payloads by reference instead of inlining them in MCP
+// responses.
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Storage is implemented by each supported backend (file, s3, gs).
+type Storage interface {
+	// Upload writes r to key and returns a URL an agent can use to fetch it.
+	Upload(ctx context.Context, key string, r io.Reader) (url string, err error)
+	// Download fetches the object stored at key.
+	Download(ctx context.Context, key string) (io.ReadCloser, error)
+	// Exists reports whether an object exists at key.
+	Exists(ctx context.Context, key string) (bool, error)
+	// URL returns a URL an agent can use to fetch the object at key without
+	// downloading it through the bridge, signing it if the backend requires it.
+	URL(ctx context.Context, key string) (string, error)
+}
+
+// New parses addr (e.g. "s3://bucket", "gs://bucket", "file:///var/blobs")
+// and returns the matching Storage backend.
+func New(addr string) (Storage, error) {
+	switch {
+	case strings.HasPrefix(addr, "s3://"):
+		return newS3Storage(strings.TrimPrefix(addr, "s3://"))
+	case strings.HasPrefix(addr, "gs://"):
+		return newGSStorage(strings.TrimPrefix(addr, "gs://"))
+	case strings.HasPrefix(addr, "file://"):
+		return newFileStorage(strings.TrimPrefix(addr, "file://"))
+	default:
+		return nil, fmt.Errorf("blob: unrecognized storage addr %q (want s3://, gs://, or file://)", addr)
+	}
+}
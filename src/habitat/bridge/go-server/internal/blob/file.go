@@ -0,0 +1,68 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// fileStorage is a Storage backend rooted at a local directory, used for
+// testing without a real bucket.
+type fileStorage struct {
+	root string
+}
+
+func newFileStorage(root string) (Storage, error) {
+	if root == "" {
+		return nil, fmt.Errorf("blob: file storage requires a root path, got file://")
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("blob: failed to create file storage root: %w", err)
+	}
+	return &fileStorage{root: root}, nil
+}
+
+func (f *fileStorage) objectPath(key string) string {
+	return filepath.Join(f.root, filepath.Clean("/"+key))
+}
+
+func (f *fileStorage) Upload(ctx context.Context, key string, r io.Reader) (string, error) {
+	path := f.objectPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("blob: failed to create directory for %s: %w", key, err)
+	}
+	out, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("blob: failed to create object %s: %w", key, err)
+	}
+	defer out.Close()
+	if _, err := io.Copy(out, r); err != nil {
+		return "", fmt.Errorf("blob: failed to write object %s: %w", key, err)
+	}
+	return f.URL(ctx, key)
+}
+
+func (f *fileStorage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(f.objectPath(key))
+	if err != nil {
+		return nil, fmt.Errorf("blob: failed to open object %s: %w", key, err)
+	}
+	return file, nil
+}
+
+func (f *fileStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := os.Stat(f.objectPath(key))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("blob: failed to stat object %s: %w", key, err)
+	}
+	return true, nil
+}
+
+func (f *fileStorage) URL(ctx context.Context, key string) (string, error) {
+	return "file://" + f.objectPath(key), nil
+}
@@ -0,0 +1,72 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileStorageObjectPathTraversal(t *testing.T) {
+	root := t.TempDir()
+	f := &fileStorage{root: root}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"a/b.txt", filepath.Join(root, "a/b.txt")},
+		{"../../../etc/passwd", filepath.Join(root, "etc/passwd")},
+		{"/../../etc/passwd", filepath.Join(root, "etc/passwd")},
+	}
+	for _, tt := range tests {
+		got := f.objectPath(tt.key)
+		if got != tt.want {
+			t.Errorf("objectPath(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+		if !strings.HasPrefix(got, root) {
+			t.Errorf("objectPath(%q) = %q escapes root %q", tt.key, got, root)
+		}
+	}
+}
+
+func TestFileStorageUploadDownload(t *testing.T) {
+	root := t.TempDir()
+	store, err := newFileStorage(root)
+	if err != nil {
+		t.Fatalf("newFileStorage returned error: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := store.Upload(ctx, "dir/object.bin", strings.NewReader("hello world")); err != nil {
+		t.Fatalf("Upload returned error: %v", err)
+	}
+
+	exists, err := store.Exists(ctx, "dir/object.bin")
+	if err != nil {
+		t.Fatalf("Exists returned error: %v", err)
+	}
+	if !exists {
+		t.Error("Exists = false after Upload, want true")
+	}
+
+	r, err := store.Download(ctx, "dir/object.bin")
+	if err != nil {
+		t.Fatalf("Download returned error: %v", err)
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read downloaded content: %v", err)
+	}
+	if string(content) != "hello world" {
+		t.Errorf("downloaded content = %q, want %q", content, "hello world")
+	}
+}
+
+func TestFileStorageMissingRoot(t *testing.T) {
+	if _, err := newFileStorage(""); err == nil {
+		t.Error("expected an error for an empty root path")
+	}
+}
@@ -0,0 +1,73 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+// gsStorage stores objects in a single GCS bucket.
+type gsStorage struct {
+	bucket string
+	client *storage.Client
+}
+
+func newGSStorage(bucket string) (Storage, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("blob: gs storage requires a bucket name, got gs://")
+	}
+	client, err := storage.NewClient(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("blob: failed to create GCS client: %w", err)
+	}
+	return &gsStorage{bucket: bucket, client: client}, nil
+}
+
+func (g *gsStorage) object(key string) *storage.ObjectHandle {
+	return g.client.Bucket(g.bucket).Object(key)
+}
+
+func (g *gsStorage) Upload(ctx context.Context, key string, r io.Reader) (string, error) {
+	w := g.object(key).NewWriter(ctx)
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return "", fmt.Errorf("blob: failed to upload gs://%s/%s: %w", g.bucket, key, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("blob: failed to finalize gs://%s/%s: %w", g.bucket, key, err)
+	}
+	return g.URL(ctx, key)
+}
+
+func (g *gsStorage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	r, err := g.object(key).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("blob: failed to download gs://%s/%s: %w", g.bucket, key, err)
+	}
+	return r, nil
+}
+
+func (g *gsStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := g.object(key).Attrs(ctx)
+	if err == storage.ErrObjectNotExist {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("blob: failed to stat gs://%s/%s: %w", g.bucket, key, err)
+	}
+	return true, nil
+}
+
+func (g *gsStorage) URL(ctx context.Context, key string) (string, error) {
+	url, err := g.client.Bucket(g.bucket).SignedURL(key, &storage.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(15 * time.Minute),
+	})
+	if err != nil {
+		return "", fmt.Errorf("blob: failed to sign gs://%s/%s: %w", g.bucket, key, err)
+	}
+	return url, nil
+}
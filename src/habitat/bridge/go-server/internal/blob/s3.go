@@ -0,0 +1,82 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3Storage stores objects in a single S3 bucket.
+type s3Storage struct {
+	bucket   string
+	client   *s3.Client
+	presign  *s3.PresignClient
+	uploader *manager.Uploader
+}
+
+func newS3Storage(bucket string) (Storage, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("blob: s3 storage requires a bucket name, got s3://")
+	}
+	cfg, err := config.LoadDefaultConfig(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("blob: failed to load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(cfg)
+	return &s3Storage{
+		bucket:   bucket,
+		client:   client,
+		presign:  s3.NewPresignClient(client),
+		uploader: manager.NewUploader(client),
+	}, nil
+}
+
+func (s *s3Storage) Upload(ctx context.Context, key string, r io.Reader) (string, error) {
+	if _, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   r,
+	}); err != nil {
+		return "", fmt.Errorf("blob: failed to upload s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return s.URL(ctx, key)
+}
+
+func (s *s3Storage) Download(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("blob: failed to download s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return out.Body, nil
+}
+
+func (s *s3Storage) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *s3Storage) URL(ctx context.Context, key string) (string, error) {
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(15*time.Minute))
+	if err != nil {
+		return "", fmt.Errorf("blob: failed to sign s3://%s/%s: %w", s.bucket, key, err)
+	}
+	return req.URL, nil
+}
@@ -0,0 +1,212 @@
+// Package gitbackend performs git operations in-process via go-git instead
+// of shelling out to the git binary, so the bridge works in minimal
+// containers and can return structured results instead of porcelain text.
+package gitbackend
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+)
+
+// GitAuth selects how Clone/Push authenticate against the remote. When more
+// than one of SSHKeyPath, AppToken, or Token is set, Method uses the first
+// of them in that order; a zero value means unauthenticated (public repo)
+// access.
+type GitAuth struct {
+	// Token is a personal access token, sent as HTTP basic auth.
+	Token string
+	// SSHKeyPath and SSHKeyPassphrase authenticate over an ssh:// remote.
+	SSHKeyPath       string
+	SSHKeyPassphrase string
+	// AppToken is a GitHub App installation token, sent the same way as
+	// Token but kept distinct so callers don't conflate the two.
+	AppToken string
+}
+
+// Method builds the go-git transport.AuthMethod for this GitAuth.
+func (a GitAuth) Method() (transport.AuthMethod, error) {
+	switch {
+	case a.SSHKeyPath != "":
+		auth, err := ssh.NewPublicKeysFromFile("git", a.SSHKeyPath, a.SSHKeyPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("gitbackend: failed to load ssh key %s: %w", a.SSHKeyPath, err)
+		}
+		return auth, nil
+	case a.AppToken != "":
+		return &http.BasicAuth{Username: "x-access-token", Password: a.AppToken}, nil
+	case a.Token != "":
+		return &http.BasicAuth{Username: "token", Password: a.Token}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// Clone clones repoURL into path using auth. If progress is non-nil, go-git
+// streams sideband progress output to it as the clone proceeds. ctx governs
+// cancellation of the clone, mirroring the exec.CommandContext behavior this
+// package replaced.
+func Clone(ctx context.Context, path, repoURL string, auth GitAuth, progress io.Writer) error {
+	method, err := auth.Method()
+	if err != nil {
+		return err
+	}
+	_, err = git.PlainCloneContext(ctx, path, false, &git.CloneOptions{
+		URL:      repoURL,
+		Auth:     method,
+		Depth:    1,
+		Progress: progress,
+	})
+	if err != nil {
+		return fmt.Errorf("gitbackend: clone failed: %w", err)
+	}
+	return nil
+}
+
+// Status is a structured view of a repo's working tree, replacing porcelain
+// text parsing downstream.
+type Status struct {
+	Branch    string   `json:"branch"`
+	Staged    []string `json:"staged"`
+	Modified  []string `json:"modified"`
+	Untracked []string `json:"untracked"`
+	Deleted   []string `json:"deleted"`
+}
+
+// GetStatus returns the structured status of the repo at path.
+func GetStatus(path string) (*Status, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return nil, fmt.Errorf("gitbackend: failed to open repo: %w", err)
+	}
+
+	head, err := repo.Head()
+	branch := ""
+	if err == nil {
+		branch = head.Name().Short()
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		return nil, fmt.Errorf("gitbackend: failed to open worktree: %w", err)
+	}
+	st, err := wt.Status()
+	if err != nil {
+		return nil, fmt.Errorf("gitbackend: failed to get status: %w", err)
+	}
+
+	result := &Status{Branch: branch}
+	for file, fileStatus := range st {
+		switch {
+		case fileStatus.Worktree == git.Deleted || fileStatus.Staging == git.Deleted:
+			result.Deleted = append(result.Deleted, file)
+		case fileStatus.Staging != git.Unmodified && fileStatus.Staging != git.Untracked:
+			result.Staged = append(result.Staged, file)
+		case fileStatus.Worktree == git.Untracked:
+			result.Untracked = append(result.Untracked, file)
+		case fileStatus.Worktree == git.Modified:
+			result.Modified = append(result.Modified, file)
+		}
+	}
+	return result, nil
+}
+
+// CommitOptions configures Commit, including optional commit signing.
+type CommitOptions struct {
+	AuthorName  string
+	AuthorEmail string
+	// GPGKeyPath, if set, signs the commit with the OpenPGP key at this path.
+	GPGKeyPath string
+}
+
+// Commit stages all changes and creates a commit with message, returning its
+// hash.
+func Commit(path, message string, opts CommitOptions) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("gitbackend: failed to open repo: %w", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return "", fmt.Errorf("gitbackend: failed to open worktree: %w", err)
+	}
+	if _, err := wt.Add("."); err != nil {
+		return "", fmt.Errorf("gitbackend: failed to stage changes: %w", err)
+	}
+
+	commitOpts := &git.CommitOptions{
+		Author: authorSignature(opts),
+	}
+	if opts.GPGKeyPath != "" {
+		entity, err := loadSigningEntity(opts.GPGKeyPath)
+		if err != nil {
+			return "", err
+		}
+		commitOpts.SignKey = entity
+	}
+
+	hash, err := wt.Commit(message, commitOpts)
+	if err != nil {
+		return "", fmt.Errorf("gitbackend: commit failed: %w", err)
+	}
+	return hash.String(), nil
+}
+
+// RemoteURL returns the first configured URL for the named remote.
+func RemoteURL(path, name string) (string, error) {
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return "", fmt.Errorf("gitbackend: failed to open repo: %w", err)
+	}
+	remote, err := repo.Remote(name)
+	if err != nil {
+		return "", fmt.Errorf("gitbackend: failed to look up remote %s: %w", name, err)
+	}
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return "", fmt.Errorf("gitbackend: remote %s has no configured URL", name)
+	}
+	return urls[0], nil
+}
+
+// Push pushes the current branch to its configured remote. If progress is
+// non-nil, go-git streams sideband progress output to it as the push
+// proceeds. ctx governs cancellation of the push, mirroring the
+// exec.CommandContext behavior this package replaced.
+func Push(ctx context.Context, path string, auth GitAuth, progress io.Writer) error {
+	method, err := auth.Method()
+	if err != nil {
+		return err
+	}
+	repo, err := git.PlainOpen(path)
+	if err != nil {
+		return fmt.Errorf("gitbackend: failed to open repo: %w", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("gitbackend: failed to resolve HEAD: %w", err)
+	}
+	remoteName := git.DefaultRemoteName
+	if branch, err := repo.Branch(head.Name().Short()); err == nil && branch.Remote != "" {
+		remoteName = branch.Remote
+	}
+	refSpec := config.RefSpec(head.Name().String() + ":" + head.Name().String())
+	if err := repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{refSpec},
+		Auth:       method,
+		Progress:   progress,
+	}); err != nil {
+		if err == git.NoErrAlreadyUpToDate {
+			return nil
+		}
+		return fmt.Errorf("gitbackend: push failed: %w", err)
+	}
+	return nil
+}
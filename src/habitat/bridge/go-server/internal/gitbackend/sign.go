@@ -0,0 +1,43 @@
+package gitbackend
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// authorSignature builds the commit author, defaulting to a bot identity
+// when opts doesn't specify one so automated commits are still attributable.
+func authorSignature(opts CommitOptions) *object.Signature {
+	name := opts.AuthorName
+	if name == "" {
+		name = "habitat-bridge"
+	}
+	email := opts.AuthorEmail
+	if email == "" {
+		email = "habitat-bridge@localhost"
+	}
+	return &object.Signature{Name: name, Email: email, When: time.Now()}
+}
+
+// loadSigningEntity reads an armored OpenPGP private key from keyPath for
+// use as a commit's SignKey.
+func loadSigningEntity(keyPath string) (*openpgp.Entity, error) {
+	f, err := os.Open(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("gitbackend: failed to open gpg key %s: %w", keyPath, err)
+	}
+	defer f.Close()
+
+	entityList, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, fmt.Errorf("gitbackend: failed to parse gpg key %s: %w", keyPath, err)
+	}
+	if len(entityList) == 0 {
+		return nil, fmt.Errorf("gitbackend: gpg key %s contains no entities", keyPath)
+	}
+	return entityList[0], nil
+}
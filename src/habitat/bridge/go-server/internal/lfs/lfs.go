@@ -0,0 +1,316 @@
+// Package lfs implements just enough of the Git LFS batch API to let the
+// bridge work against repos with LFS-tracked assets without requiring the
+// container image to ship the git-lfs binary.
+package lfs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const pointerHeader = "version https://git-lfs.github.com/spec/v1"
+
+// Pointer is the parsed contents of a Git LFS pointer file.
+type Pointer struct {
+	OID  string // sha256 hex digest, without the "sha256:" prefix
+	Size int64
+}
+
+// Client fetches and uploads LFS objects against a repo's configured LFS
+// server, authenticating with a bearer token (typically GITHUB_TOKEN).
+type Client struct {
+	HTTPClient *http.Client
+	Token      string
+}
+
+// NewClient returns a Client authenticating with token (may be empty for
+// public repos).
+func NewClient(token string) *Client {
+	return &Client{HTTPClient: http.DefaultClient, Token: token}
+}
+
+// TrackedPatterns reads .gitattributes at repoPath and returns the glob
+// patterns configured with the "filter=lfs" attribute.
+func TrackedPatterns(repoPath string) ([]string, error) {
+	f, err := os.Open(filepath.Join(repoPath, ".gitattributes"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lfs: failed to read .gitattributes: %w", err)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		for _, attr := range fields[1:] {
+			if attr == "filter=lfs" {
+				patterns = append(patterns, fields[0])
+				break
+			}
+		}
+	}
+	return patterns, scanner.Err()
+}
+
+// MatchesAnyPattern reports whether the base name of path matches one of the
+// given .gitattributes glob patterns. Only the base name is compared, so a
+// pattern that contains a directory part never matches.
+func MatchesAnyPattern(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, filepath.Base(path)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePointer parses the contents of an LFS pointer file.
+func ParsePointer(content []byte) (Pointer, error) {
+	if !bytes.HasPrefix(content, []byte(pointerHeader)) {
+		return Pointer{}, fmt.Errorf("lfs: not a pointer file")
+	}
+	var p Pointer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "oid sha256:"):
+			p.OID = strings.TrimPrefix(line, "oid sha256:")
+		case strings.HasPrefix(line, "size "):
+			size, err := strconv.ParseInt(strings.TrimPrefix(line, "size "), 10, 64)
+			if err != nil {
+				return Pointer{}, fmt.Errorf("lfs: invalid size in pointer: %w", err)
+			}
+			p.Size = size
+		}
+	}
+	if p.OID == "" {
+		return Pointer{}, fmt.Errorf("lfs: pointer file missing oid")
+	}
+	return p, nil
+}
+
+// WritePointer builds the canonical pointer file contents for an object.
+func WritePointer(oid string, size int64) []byte {
+	return []byte(fmt.Sprintf("%s\noid sha256:%s\nsize %d\n", pointerHeader, oid, size))
+}
+
+// HashObject returns the sha256 pointer for the given object contents.
+func HashObject(content []byte) Pointer {
+	sum := sha256.Sum256(content)
+	return Pointer{OID: hex.EncodeToString(sum[:]), Size: int64(len(content))}
+}
+
+type batchRequest struct {
+	Operation string        `json:"operation"`
+	Transfers []string      `json:"transfers"`
+	Objects   []batchObject `json:"objects"`
+}
+
+type batchObject struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type batchResponse struct {
+	Objects []struct {
+		OID     string `json:"oid"`
+		Size    int64  `json:"size"`
+		Actions map[string]struct {
+			Href   string            `json:"href"`
+			Header map[string]string `json:"header"`
+		} `json:"actions"`
+		Error *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// batch calls the LFS server's batch API for the given operation ("download"
+// or "upload") and returns the decoded response, one entry per object with
+// its transfer actions or error.
+func (c *Client) batch(ctx context.Context, lfsServer, operation string, pointers []Pointer) (*batchResponse, error) {
+	objects := make([]batchObject, len(pointers))
+	for i, p := range pointers {
+		objects[i] = batchObject{OID: p.OID, Size: p.Size}
+	}
+
+	body, err := json.Marshal(batchRequest{Operation: operation, Transfers: []string{"basic"}, Objects: objects})
+	if err != nil {
+		return nil, fmt.Errorf("lfs: failed to encode batch request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(lfsServer, "/")+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("lfs: failed to build batch request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+	req.Header.Set("Accept", "application/vnd.git-lfs+json")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("lfs: batch request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lfs: batch request returned %s", resp.Status)
+	}
+
+	var out batchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("lfs: failed to decode batch response: %w", err)
+	}
+	return &out, nil
+}
+
+// Fetch downloads the objects for pointers from lfsServer into cacheDir,
+// named by oid, skipping objects already cached.
+func (c *Client) Fetch(ctx context.Context, lfsServer, cacheDir string, pointers []Pointer) error {
+	var missing []Pointer
+	for _, p := range pointers {
+		if _, err := os.Stat(filepath.Join(cacheDir, p.OID)); err == nil {
+			continue
+		}
+		missing = append(missing, p)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	resp, err := c.batch(ctx, lfsServer, "download", missing)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return fmt.Errorf("lfs: failed to create cache dir: %w", err)
+	}
+
+	for _, obj := range resp.Objects {
+		if obj.Error != nil {
+			return fmt.Errorf("lfs: server error for %s: %s", obj.OID, obj.Error.Message)
+		}
+		action, ok := obj.Actions["download"]
+		if !ok {
+			continue
+		}
+		if err := c.download(ctx, action.Href, action.Header, filepath.Join(cacheDir, obj.OID)); err != nil {
+			return fmt.Errorf("lfs: failed to fetch object %s: %w", obj.OID, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) download(ctx context.Context, href string, headers map[string]string, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download returned %s", resp.Status)
+	}
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	_, err = io.Copy(out, resp.Body)
+	return err
+}
+
+// Upload pushes the object at localPath (named by its oid/size pointer) to
+// lfsServer.
+func (c *Client) Upload(ctx context.Context, lfsServer string, p Pointer, localPath string) error {
+	resp, err := c.batch(ctx, lfsServer, "upload", []Pointer{p})
+	if err != nil {
+		return err
+	}
+	if len(resp.Objects) == 0 {
+		return fmt.Errorf("lfs: empty batch response for upload of %s", p.OID)
+	}
+	obj := resp.Objects[0]
+	if obj.Error != nil {
+		return fmt.Errorf("lfs: server error for %s: %s", obj.OID, obj.Error.Message)
+	}
+	action, ok := obj.Actions["upload"]
+	if !ok {
+		// Server already has the object.
+		return nil
+	}
+
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return fmt.Errorf("lfs: failed to read object to upload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, action.Href, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+	httpResp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("lfs: upload request failed: %w", err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode/100 != 2 {
+		return fmt.Errorf("lfs: upload returned %s", httpResp.Status)
+	}
+	return nil
+}
+
+// Prune removes cached objects in cacheDir not referenced by keep.
+func Prune(cacheDir string, keep map[string]bool) (int, error) {
+	entries, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("lfs: failed to list cache dir: %w", err)
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if keep[e.Name()] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(cacheDir, e.Name())); err != nil {
+			return removed, fmt.Errorf("lfs: failed to prune %s: %w", e.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
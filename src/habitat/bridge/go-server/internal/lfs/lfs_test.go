@@ -0,0 +1,72 @@
+package lfs
+
+import "testing"
+
+func TestParsePointer(t *testing.T) {
+	content := []byte(pointerHeader + "\n" +
+		"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\n" +
+		"size 1234\n")
+
+	p, err := ParsePointer(content)
+	if err != nil {
+		t.Fatalf("ParsePointer returned error: %v", err)
+	}
+	if p.OID != "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393" {
+		t.Errorf("OID = %q, want the hex digest after oid sha256:", p.OID)
+	}
+	if p.Size != 1234 {
+		t.Errorf("Size = %d, want 1234", p.Size)
+	}
+}
+
+func TestParsePointerNotAPointerFile(t *testing.T) {
+	if _, err := ParsePointer([]byte("just some regular file content\n")); err == nil {
+		t.Error("expected an error for content without the pointer header")
+	}
+}
+
+func TestParsePointerMissingOID(t *testing.T) {
+	content := []byte(pointerHeader + "\nsize 1234\n")
+	if _, err := ParsePointer(content); err == nil {
+		t.Error("expected an error for a pointer file missing oid")
+	}
+}
+
+func TestParsePointerInvalidSize(t *testing.T) {
+	content := []byte(pointerHeader + "\noid sha256:abc\nsize not-a-number\n")
+	if _, err := ParsePointer(content); err == nil {
+		t.Error("expected an error for a pointer file with a non-numeric size")
+	}
+}
+
+func TestWritePointerRoundTrip(t *testing.T) {
+	oid := "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	content := WritePointer(oid, 42)
+
+	p, err := ParsePointer(content)
+	if err != nil {
+		t.Fatalf("ParsePointer(WritePointer(...)) returned error: %v", err)
+	}
+	if p.OID != oid || p.Size != 42 {
+		t.Errorf("got {OID: %q, Size: %d}, want {OID: %q, Size: 42}", p.OID, p.Size, oid)
+	}
+}
+
+func TestMatchesAnyPattern(t *testing.T) {
+	patterns := []string{"*.psd", "*.bin"}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"assets/logo.psd", true},
+		{"deep/nested/dir/data.bin", true},
+		{"readme.md", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := MatchesAnyPattern(patterns, tt.path); got != tt.want {
+			t.Errorf("MatchesAnyPattern(%v, %q) = %v, want %v", patterns, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package streamio
+
+import (
+	"bytes"
+	"sync"
+)
+
+// LineWriter is an io.Writer that splits whatever is written to it on '\n'
+// and invokes OnLine for each complete line, tagging it with a monotonic
+// sequence number. Used to turn CombinedOutput-style byte streams (exec
+// output, go-git clone/push progress) into discrete progress events.
+//
+// Write/Flush may be called concurrently (e.g. a command's stdout and
+// stderr copied in from separate goroutines), so partial/seq are guarded
+// by mu.
+type LineWriter struct {
+	OnLine func(line string, seq int64)
+	// OnHeartbeat, if set, is called by the owner on an idle tick so slow
+	// commands still show signs of life between lines.
+	OnHeartbeat func(seq int64)
+
+	mu      sync.Mutex
+	partial []byte
+	seq     int64
+}
+
+// Seq returns the number of lines emitted so far.
+func (w *LineWriter) Seq() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.seq
+}
+
+// Heartbeat invokes OnHeartbeat, if set, with the current sequence number.
+func (w *LineWriter) Heartbeat() {
+	if w.OnHeartbeat != nil {
+		w.OnHeartbeat(w.Seq())
+	}
+}
+
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(bytes.TrimRight(w.partial[:i], "\r"))
+		w.partial = w.partial[i+1:]
+		if w.OnLine != nil {
+			w.seq++
+			w.OnLine(line, w.seq)
+		}
+	}
+	return len(p), nil
+}
+
+// Flush emits any trailing partial line that was never newline-terminated.
+func (w *LineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) == 0 {
+		return
+	}
+	line := string(w.partial)
+	w.partial = nil
+	if w.OnLine != nil {
+		w.seq++
+		w.OnLine(line, w.seq)
+	}
+}
@@ -0,0 +1,40 @@
+// Package streamio holds the line-writer / ring-buffer helpers shared by
+// exec_run and git_clone/git_push to stream output as MCP progress
+// notifications instead of buffering it all in memory.
+package streamio
+
+import "sync"
+
+// RingBuffer is an io.Writer that keeps only the most recent max bytes
+// written to it, so long-running commands don't grow memory unbounded.
+type RingBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+// NewRingBuffer returns a RingBuffer retaining at most max bytes.
+func NewRingBuffer(max int) *RingBuffer {
+	return &RingBuffer{max: max}
+}
+
+func (r *RingBuffer) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buf = append(r.buf, p...)
+	if len(r.buf) > r.max {
+		r.buf = r.buf[len(r.buf)-r.max:]
+	}
+	return len(p), nil
+}
+
+// Bytes returns a copy of the currently retained contents.
+func (r *RingBuffer) Bytes() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	out := make([]byte, len(r.buf))
+	copy(out, r.buf)
+	return out
+}
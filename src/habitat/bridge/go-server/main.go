@@ -1,27 +1,66 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/The-Focus-AI/umwelten/src/habitat/bridge/go-server/internal/blob"
+	"github.com/The-Focus-AI/umwelten/src/habitat/bridge/go-server/internal/gitbackend"
+	"github.com/The-Focus-AI/umwelten/src/habitat/bridge/go-server/internal/lfs"
+	"github.com/The-Focus-AI/umwelten/src/habitat/bridge/go-server/internal/streamio"
 )
 
 var (
-	port = flag.Int("port", 8080, "port to listen on")
+	port             = flag.Int("port", 8080, "port to listen on")
+	storageAddr      = flag.String("storage-addr", "", "blob storage backend for large file I/O (s3://bucket, gs://bucket, file:///path)")
+	sshKeyPath       = flag.String("ssh-key-path", "", "SSH private key used to authenticate git clone/push")
+	sshKeyPassphrase = flag.String("ssh-key-passphrase", "", "passphrase for -ssh-key-path")
+	githubAppToken   = flag.String("github-app-token", "", "GitHub App installation token used to authenticate git clone/push")
+	gpgKeyPath       = flag.String("gpg-key-path", "", "armored OpenPGP private key used to sign commits when signCommit is requested")
+	blobThreshold    = flag.Int64("blob-threshold", 1<<20, "size in bytes above which fs_read/exec_run offload content to the configured blob store instead of inlining it")
 )
 
+// store is the active blob backend, or nil if --storage-addr was not set.
+var store blob.Storage
+
+// gitAuth is built once from flags/env and used by every git_clone/git_push.
+var gitAuth gitbackend.GitAuth
+
 func main() {
 	flag.Parse()
 
+	if *storageAddr != "" {
+		s, err := blob.New(*storageAddr)
+		if err != nil {
+			log.Fatalf("Failed to configure blob storage: %v", err)
+		}
+		store = s
+	}
+
+	gitAuth = gitbackend.GitAuth{
+		Token:            os.Getenv("GITHUB_TOKEN"),
+		SSHKeyPath:       *sshKeyPath,
+		SSHKeyPassphrase: *sshKeyPassphrase,
+		AppToken:         *githubAppToken,
+	}
+
 	// Create MCP server
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "habitat-bridge",
@@ -49,71 +88,89 @@ func registerTools(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "git_clone",
 		Description: "Clone a git repository",
-	}, handleGitClone)
+	}, instrument("git_clone", handleGitClone))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "git_status",
 		Description: "Get git status",
-	}, handleGitStatus)
+	}, instrument("git_status", handleGitStatus))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "git_commit",
 		Description: "Commit changes",
-	}, handleGitCommit)
+	}, instrument("git_commit", handleGitCommit))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "git_push",
 		Description: "Push changes",
-	}, handleGitPush)
+	}, instrument("git_push", handleGitPush))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "lfs_pull",
+		Description: "Fetch Git LFS objects referenced by pointer files into the local cache",
+	}, instrument("lfs_pull", handleLfsPull))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "lfs_prune",
+		Description: "Remove cached Git LFS objects no longer referenced by any pointer file",
+	}, instrument("lfs_prune", handleLfsPrune))
 
 	// File system tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "fs_read",
 		Description: "Read a file",
-	}, handleFsRead)
+	}, instrument("fs_read", handleFsRead))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "fs_write",
 		Description: "Write a file",
-	}, handleFsWrite)
+	}, instrument("fs_write", handleFsWrite))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "fs_list",
 		Description: "List directory contents",
-	}, handleFsList)
+	}, instrument("fs_list", handleFsList))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "fs_exists",
 		Description: "Check if a path exists",
-	}, handleFsExists)
+	}, instrument("fs_exists", handleFsExists))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "fs_stat",
 		Description: "Get file/directory stats",
-	}, handleFsStat)
+	}, instrument("fs_stat", handleFsStat))
 
 	// Execution tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "exec_run",
 		Description: "Execute a command",
-	}, handleExecRun)
+	}, instrument("exec_run", handleExecRun))
 
 	// Bridge tools
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "bridge_health",
 		Description: "Check bridge health",
-	}, handleBridgeHealth)
+	}, instrument("bridge_health", handleBridgeHealth))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "bridge_logs",
 		Description: "Get bridge logs",
-	}, handleBridgeLogs)
+	}, instrument("bridge_logs", handleBridgeLogs))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "bridge_metrics",
+		Description: "Get per-tool invocation counts, error counts, latency percentiles, and I/O byte counts",
+	}, instrument("bridge_metrics", handleBridgeMetrics))
 }
 
 // Tool parameter types
 type GitCloneParams struct {
-	RepoURL string `json:"repoUrl" jsonschema:"Git repository URL to clone"`
-	Path    string `json:"path,omitempty" jsonschema:"Target directory (defaults to /workspace)"`
+	RepoURL    string   `json:"repoUrl" jsonschema:"Git repository URL to clone"`
+	Path       string   `json:"path,omitempty" jsonschema:"Target directory (defaults to /workspace)"`
+	Lfs        bool     `json:"lfs,omitempty" jsonschema:"Fetch Git LFS objects referenced by pointer files after cloning"`
+	LfsInclude []string `json:"lfsInclude,omitempty" jsonschema:"Glob patterns of LFS paths to fetch (defaults to everything tracked in .gitattributes)"`
+	Stream     bool     `json:"stream,omitempty" jsonschema:"Emit clone progress as MCP progress notifications"`
 }
 
 type GitStatusParams struct {
@@ -121,11 +178,24 @@ type GitStatusParams struct {
 }
 
 type GitCommitParams struct {
-	Message string `json:"message" jsonschema:"Commit message"`
-	Path    string `json:"path,omitempty" jsonschema:"Repository path (defaults to /workspace)"`
+	Message    string `json:"message" jsonschema:"Commit message"`
+	Path       string `json:"path,omitempty" jsonschema:"Repository path (defaults to /workspace)"`
+	Lfs        bool   `json:"lfs,omitempty" jsonschema:"Upload any new LFS objects referenced by pointer files as part of this commit"`
+	SignCommit bool   `json:"signCommit,omitempty" jsonschema:"Sign the commit with the server's configured gpg key (-gpg-key-path)"`
 }
 
 type GitPushParams struct {
+	Path   string `json:"path,omitempty" jsonschema:"Repository path (defaults to /workspace)"`
+	Lfs    bool   `json:"lfs,omitempty" jsonschema:"Transfer pending LFS objects to the LFS server before pushing"`
+	Stream bool   `json:"stream,omitempty" jsonschema:"Emit push progress as MCP progress notifications"`
+}
+
+type LfsPullParams struct {
+	Path       string   `json:"path,omitempty" jsonschema:"Repository path (defaults to /workspace)"`
+	LfsInclude []string `json:"lfsInclude,omitempty" jsonschema:"Glob patterns of LFS paths to fetch (defaults to everything tracked in .gitattributes)"`
+}
+
+type LfsPruneParams struct {
 	Path string `json:"path,omitempty" jsonschema:"Repository path (defaults to /workspace)"`
 }
 
@@ -133,9 +203,14 @@ type FsReadParams struct {
 	Path string `json:"path" jsonschema:"File path to read"`
 }
 
+type BlobRef struct {
+	Key string `json:"key" jsonschema:"Object key within the server's configured --storage-addr backend"`
+}
+
 type FsWriteParams struct {
-	Path    string `json:"path" jsonschema:"File path to write"`
-	Content string `json:"content" jsonschema:"Content to write"`
+	Path    string   `json:"path" jsonschema:"File path to write"`
+	Content string   `json:"content,omitempty" jsonschema:"Content to write (mutually exclusive with blobRef)"`
+	BlobRef *BlobRef `json:"blobRef,omitempty" jsonschema:"Fetch content from blob storage instead of inlining it"`
 }
 
 type FsListParams struct {
@@ -151,35 +226,213 @@ type FsStatParams struct {
 }
 
 type ExecRunParams struct {
-	Command string `json:"command" jsonschema:"Command to execute"`
-	Timeout int    `json:"timeout,omitempty" jsonschema:"Timeout in milliseconds (default: 60000)"`
-	Cwd     string `json:"cwd,omitempty" jsonschema:"Working directory (default: /workspace)"`
+	Command        string `json:"command" jsonschema:"Command to execute"`
+	Timeout        int    `json:"timeout,omitempty" jsonschema:"Timeout in milliseconds (default: 60000)"`
+	Cwd            string `json:"cwd,omitempty" jsonschema:"Working directory (default: /workspace)"`
+	Stream         bool   `json:"stream,omitempty" jsonschema:"Emit output as MCP progress notifications as the command runs, instead of only returning it at the end"`
+	MaxBufferBytes int    `json:"maxBufferBytes,omitempty" jsonschema:"Size of the trailing output buffer returned in the final result (default: 4 MiB)"`
+	HeartbeatMs    int    `json:"heartbeatMs,omitempty" jsonschema:"Interval between heartbeat progress notifications when no output has arrived (default: 5000, only used when stream is true)"`
 }
 
 type BridgeLogsParams struct {
-	Lines int `json:"lines,omitempty" jsonschema:"Number of log lines to return (default: 100)"`
+	Lines int    `json:"lines,omitempty" jsonschema:"Number of log lines to return (default: 100)"`
+	Level string `json:"level,omitempty" jsonschema:"Minimum severity to return: debug, info, warn, or error (default: debug, i.e. everything)"`
+	Tool  string `json:"tool,omitempty" jsonschema:"Only return log entries emitted by this tool name"`
+	Since string `json:"since,omitempty" jsonschema:"Only return log entries at or after this RFC3339 timestamp"`
+	Grep  string `json:"grep,omitempty" jsonschema:"Only return log entries whose message or fields match this substring or regexp"`
 }
 
-// Log buffer for bridge_logs
+type ToolMetricsParams struct{}
+
+// logMu guards logBuffer, which is written to from concurrent tool
+// invocations (e.g. the streaming exec_run goroutines).
+var logMu sync.Mutex
+
+// logBuffer is the in-memory ring of recent log entries served by
+// bridge_logs.
 var logBuffer []LogEntry
 
+// LogEntry is a single structured log line. Fields carries the kv pairs
+// passed to logMsg, in the style of log15.
 type LogEntry struct {
-	Timestamp string `json:"timestamp"`
-	Level     string `json:"level"`
-	Message   string `json:"message"`
+	Timestamp time.Time      `json:"timestamp"`
+	Level     string         `json:"level"`
+	Message   string         `json:"message"`
+	Fields    map[string]any `json:"fields,omitempty"`
+	ToolName  string         `json:"toolName,omitempty"`
+	RequestID string         `json:"requestId,omitempty"`
 }
 
-func logMsg(level, message string) {
+// logLevels gives severities an order so bridge_logs can filter by a
+// minimum level.
+var logLevels = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
+// logMsg records a structured log entry tagged with the tool name and
+// request ID carried in ctx (set by instrument), plus any kv pairs, e.g.
+// logMsg(ctx, "info", "cloning", "repoUrl", repoURL, "path", path).
+func logMsg(ctx context.Context, level, message string, kv ...any) {
+	var fields map[string]any
+	if len(kv) > 0 {
+		fields = make(map[string]any, len(kv)/2)
+		for i := 0; i+1 < len(kv); i += 2 {
+			key, ok := kv[i].(string)
+			if !ok {
+				continue
+			}
+			fields[key] = kv[i+1]
+		}
+	}
+
 	entry := LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: time.Now(),
 		Level:     level,
 		Message:   message,
+		Fields:    fields,
+		ToolName:  toolNameFromContext(ctx),
+		RequestID: requestIDFromContext(ctx),
 	}
+
+	logMu.Lock()
 	logBuffer = append(logBuffer, entry)
 	if len(logBuffer) > 1000 {
-		logBuffer = logBuffer[1:]
+		logBuffer = logBuffer[len(logBuffer)-1000:]
+	}
+	logMu.Unlock()
+
+	fmt.Fprintf(os.Stderr, "[%s] %s tool=%s req=%s: %s %v\n",
+		entry.Timestamp.Format(time.RFC3339), level, entry.ToolName, entry.RequestID, message, fields)
+}
+
+type ctxKey int
+
+const (
+	ctxKeyToolName ctxKey = iota
+	ctxKeyRequestID
+	ctxKeyIOCounters
+)
+
+func toolNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(ctxKeyToolName).(string)
+	return name
+}
+
+func requestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKeyRequestID).(string)
+	return id
+}
+
+// ioCounters accumulates the actual bytes a single handler invocation reads
+// from or writes to disk/blob storage, so bridge_metrics reflects real I/O
+// instead of MCP request/response payload sizes.
+type ioCounters struct {
+	read    int64
+	written int64
+}
+
+// addBytesRead records n bytes of I/O read by the handler running under ctx.
+// It's a no-op if ctx wasn't produced by instrument().
+func addBytesRead(ctx context.Context, n int) {
+	if c, ok := ctx.Value(ctxKeyIOCounters).(*ioCounters); ok {
+		atomic.AddInt64(&c.read, int64(n))
+	}
+}
+
+// addBytesWritten records n bytes of I/O written by the handler running
+// under ctx. It's a no-op if ctx wasn't produced by instrument().
+func addBytesWritten(ctx context.Context, n int) {
+	if c, ok := ctx.Value(ctxKeyIOCounters).(*ioCounters); ok {
+		atomic.AddInt64(&c.written, int64(n))
+	}
+}
+
+// requestSeq generates monotonically increasing, process-unique request IDs.
+var requestSeq int64
+
+func nextRequestID() string {
+	return fmt.Sprintf("req-%d", atomic.AddInt64(&requestSeq, 1))
+}
+
+// toolMetrics accumulates counters and latency samples for a single tool.
+// durations is capped so memory stays bounded on long-running servers; the
+// percentiles it yields become approximate once samples roll off, which is
+// an acceptable tradeoff for an operational dashboard.
+type toolMetrics struct {
+	mu           sync.Mutex
+	invocations  int64
+	errors       int64
+	bytesRead    int64
+	bytesWritten int64
+	durations    []time.Duration
+}
+
+const maxDurationSamples = 1000
+
+func (m *toolMetrics) record(dur time.Duration, isErr bool, bytesIn, bytesOut int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.invocations++
+	if isErr {
+		m.errors++
+	}
+	m.bytesRead += bytesIn
+	m.bytesWritten += bytesOut
+	m.durations = append(m.durations, dur)
+	if len(m.durations) > maxDurationSamples {
+		m.durations = m.durations[len(m.durations)-maxDurationSamples:]
+	}
+}
+
+// percentile returns the p-th percentile (0-100) of the recorded durations.
+// Callers must hold m.mu.
+func (m *toolMetrics) percentile(p float64) time.Duration {
+	if len(m.durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(m.durations))
+	copy(sorted, m.durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(p / 100 * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+var (
+	metricsMu     sync.Mutex
+	metricsByTool = map[string]*toolMetrics{}
+)
+
+func metricsFor(toolName string) *toolMetrics {
+	metricsMu.Lock()
+	defer metricsMu.Unlock()
+	m, ok := metricsByTool[toolName]
+	if !ok {
+		m = &toolMetrics{}
+		metricsByTool[toolName] = m
+	}
+	return m
+}
+
+// instrument wraps a tool handler so every call is tagged with a tool name
+// and request ID (available to handlers via logMsg's ctx argument) and
+// recorded into bridge_metrics. Handlers report their actual I/O via
+// addBytesRead/addBytesWritten as they perform it; instrument reads those
+// counters back out rather than estimating from params/result payload size.
+func instrument[P any](toolName string, handler func(context.Context, *mcp.CallToolRequest, *P) (*mcp.CallToolResult, any, error)) func(context.Context, *mcp.CallToolRequest, *P) (*mcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, params *P) (*mcp.CallToolResult, any, error) {
+		ctx = context.WithValue(ctx, ctxKeyToolName, toolName)
+		ctx = context.WithValue(ctx, ctxKeyRequestID, nextRequestID())
+		counters := &ioCounters{}
+		ctx = context.WithValue(ctx, ctxKeyIOCounters, counters)
+
+		start := time.Now()
+		result, data, err := handler(ctx, req, params)
+		dur := time.Since(start)
+
+		isErr := err != nil || (result != nil && result.IsError)
+		metricsFor(toolName).record(dur, isErr, atomic.LoadInt64(&counters.read), atomic.LoadInt64(&counters.written))
+
+		return result, data, err
 	}
-	fmt.Fprintf(os.Stderr, "[%s] %s: %s\n", entry.Timestamp, level, message)
 }
 
 func resolvePath(inputPath string) string {
@@ -196,6 +449,91 @@ func isAllowedPath(path string) bool {
 	return strings.HasPrefix(path, "/workspace") || strings.HasPrefix(path, "/opt")
 }
 
+// repoRoot walks up from path looking for a .git directory, so LFS handling
+// works for repos cloned anywhere under an allowed root (not just
+// /workspace). It falls back to /workspace if no .git is found.
+func repoRoot(path string) string {
+	dir := filepath.Dir(path)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || !isAllowedPath(parent) {
+			break
+		}
+		dir = parent
+	}
+	return "/workspace"
+}
+
+// dirSize sums the size of every regular file under root, skipping .git, so
+// callers can approximate the amount of repo content a clone/push actually
+// moved.
+func dirSize(root string) (int64, error) {
+	var total int64
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// changedFilesSize sums the on-disk size of every staged/modified file in
+// status, approximating the bytes a commit is about to write into the git
+// object store. Unreadable or already-deleted files are skipped rather than
+// failing the whole estimate.
+func changedFilesSize(repoPath string, status *gitbackend.Status) int {
+	var total int
+	for _, files := range [][]string{status.Staged, status.Modified} {
+		for _, f := range files {
+			if info, err := os.Stat(filepath.Join(repoPath, f)); err == nil {
+				total += int(info.Size())
+			}
+		}
+	}
+	return total
+}
+
+// newProgressNotifier builds a streamio.LineWriter that forwards each line
+// (and each heartbeat) as an MCP progress notification, tagged with the
+// progress token the caller attached to this request. If the caller didn't
+// ask for progress (no token), the returned writer is inert.
+func newProgressNotifier(ctx context.Context, req *mcp.CallToolRequest) *streamio.LineWriter {
+	var token any
+	if req.Params != nil {
+		token = req.Params.GetProgressToken()
+	}
+
+	notify := func(seq int64, message string) {
+		if token == nil {
+			return
+		}
+		req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      float64(seq),
+			Message:       message,
+		})
+	}
+
+	return &streamio.LineWriter{
+		OnLine:      func(line string, seq int64) { notify(seq, line) },
+		OnHeartbeat: func(seq int64) { notify(seq, "") },
+	}
+}
+
 // Tool handlers
 func handleGitClone(ctx context.Context, req *mcp.CallToolRequest, params *GitCloneParams) (*mcp.CallToolResult, any, error) {
 	targetPath := params.Path
@@ -203,22 +541,35 @@ func handleGitClone(ctx context.Context, req *mcp.CallToolRequest, params *GitCl
 		targetPath = "/workspace"
 	}
 
-	logMsg("info", fmt.Sprintf("Cloning %s to %s", params.RepoURL, targetPath))
+	logMsg(ctx, "info", fmt.Sprintf("Cloning %s to %s", params.RepoURL, targetPath), "repoUrl", params.RepoURL, "path", targetPath)
 
-	cmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", params.RepoURL, targetPath)
-	cmd.Env = os.Environ()
-	if os.Getenv("GITHUB_TOKEN") != "" {
-		cmd.Env = append(cmd.Env, "GIT_ASKPASS=echo", "GIT_USERNAME=token", "GIT_PASSWORD="+os.Getenv("GITHUB_TOKEN"))
+	var progress io.Writer
+	if params.Stream {
+		notifier := newProgressNotifier(ctx, req)
+		defer notifier.Flush()
+		progress = notifier
 	}
 
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return nil, nil, fmt.Errorf("git clone failed: %w\nOutput: %s", err, string(output))
+	if err := gitbackend.Clone(ctx, targetPath, params.RepoURL, gitAuth, progress); err != nil {
+		return nil, nil, fmt.Errorf("git clone failed: %w", err)
+	}
+
+	resultText := fmt.Sprintf("Successfully cloned %s to %s", params.RepoURL, targetPath)
+	if params.Lfs {
+		fetched, err := lfsFetchAll(ctx, targetPath, params.LfsInclude)
+		if err != nil {
+			return nil, nil, fmt.Errorf("git clone succeeded but lfs fetch failed: %w", err)
+		}
+		resultText += fmt.Sprintf("\nFetched %d LFS object(s)", fetched)
+	}
+
+	if size, err := dirSize(targetPath); err == nil {
+		addBytesWritten(ctx, int(size))
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Successfully cloned %s to %s", params.RepoURL, targetPath)},
+			&mcp.TextContent{Text: resultText},
 		},
 	}, nil, nil
 }
@@ -226,15 +577,22 @@ func handleGitClone(ctx context.Context, req *mcp.CallToolRequest, params *GitCl
 func handleGitStatus(ctx context.Context, req *mcp.CallToolRequest, params *GitStatusParams) (*mcp.CallToolResult, any, error) {
 	repoPath := resolvePath(params.Path)
 
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "status", "--porcelain")
-	output, err := cmd.Output()
+	status, err := gitbackend.GetStatus(repoPath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("git status failed: %w", err)
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Git status for %s:\n%s", repoPath, string(output))},
+			&mcp.TextContent{Text: fmt.Sprintf("Git status for %s (branch %s): %d staged, %d modified, %d untracked, %d deleted",
+				repoPath, status.Branch, len(status.Staged), len(status.Modified), len(status.Untracked), len(status.Deleted))},
+		},
+		Meta: map[string]any{
+			"branch":    status.Branch,
+			"staged":    status.Staged,
+			"modified":  status.Modified,
+			"untracked": status.Untracked,
+			"deleted":   status.Deleted,
 		},
 	}, nil, nil
 }
@@ -242,20 +600,35 @@ func handleGitStatus(ctx context.Context, req *mcp.CallToolRequest, params *GitS
 func handleGitCommit(ctx context.Context, req *mcp.CallToolRequest, params *GitCommitParams) (*mcp.CallToolResult, any, error) {
 	repoPath := resolvePath(params.Path)
 
-	addCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "add", "-A")
-	if err := addCmd.Run(); err != nil {
-		return nil, nil, fmt.Errorf("git add failed: %w", err)
+	opts := gitbackend.CommitOptions{}
+	if params.SignCommit {
+		if *gpgKeyPath == "" {
+			return nil, nil, fmt.Errorf("signCommit requested but no -gpg-key-path is configured")
+		}
+		opts.GPGKeyPath = *gpgKeyPath
+	}
+
+	if status, err := gitbackend.GetStatus(repoPath); err == nil {
+		addBytesWritten(ctx, changedFilesSize(repoPath, status))
 	}
 
-	commitCmd := exec.CommandContext(ctx, "git", "-C", repoPath, "commit", "-m", params.Message)
-	output, err := commitCmd.CombinedOutput()
+	hash, err := gitbackend.Commit(repoPath, params.Message, opts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("git commit failed: %w\nOutput: %s", err, string(output))
+		return nil, nil, fmt.Errorf("git commit failed: %w", err)
+	}
+
+	if params.Lfs {
+		if err := lfsUploadAll(ctx, repoPath); err != nil {
+			return nil, nil, fmt.Errorf("git commit succeeded but lfs upload failed: %w", err)
+		}
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Committed changes: %s\n%s", params.Message, string(output))},
+			&mcp.TextContent{Text: fmt.Sprintf("Committed changes: %s (%s)", params.Message, hash)},
+		},
+		Meta: map[string]any{
+			"hash": hash,
 		},
 	}, nil, nil
 }
@@ -263,24 +636,248 @@ func handleGitCommit(ctx context.Context, req *mcp.CallToolRequest, params *GitC
 func handleGitPush(ctx context.Context, req *mcp.CallToolRequest, params *GitPushParams) (*mcp.CallToolResult, any, error) {
 	repoPath := resolvePath(params.Path)
 
-	cmd := exec.CommandContext(ctx, "git", "-C", repoPath, "push")
-	cmd.Env = os.Environ()
-	if os.Getenv("GITHUB_TOKEN") != "" {
-		cmd.Env = append(cmd.Env, "GIT_ASKPASS=echo", "GIT_USERNAME=token", "GIT_PASSWORD="+os.Getenv("GITHUB_TOKEN"))
+	if params.Lfs {
+		if err := lfsUploadAll(ctx, repoPath); err != nil {
+			return nil, nil, fmt.Errorf("pre-push lfs transfer failed: %w", err)
+		}
+	}
+
+	var progress io.Writer
+	if params.Stream {
+		notifier := newProgressNotifier(ctx, req)
+		defer notifier.Flush()
+		progress = notifier
 	}
 
-	output, err := cmd.CombinedOutput()
+	if size, err := dirSize(repoPath); err == nil {
+		addBytesRead(ctx, int(size))
+	}
+
+	if err := gitbackend.Push(ctx, repoPath, gitAuth, progress); err != nil {
+		return nil, nil, fmt.Errorf("git push failed: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Pushed changes to remote for %s", repoPath)},
+		},
+	}, nil, nil
+}
+
+func handleLfsPull(ctx context.Context, req *mcp.CallToolRequest, params *LfsPullParams) (*mcp.CallToolResult, any, error) {
+	repoPath := resolvePath(params.Path)
+
+	fetched, err := lfsFetchAll(ctx, repoPath, params.LfsInclude)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lfs pull failed: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: fmt.Sprintf("Fetched %d LFS object(s) into %s", fetched, lfsCacheDir(repoPath))},
+		},
+	}, nil, nil
+}
+
+func handleLfsPrune(ctx context.Context, req *mcp.CallToolRequest, params *LfsPruneParams) (*mcp.CallToolResult, any, error) {
+	repoPath := resolvePath(params.Path)
+
+	patterns, err := lfs.TrackedPatterns(repoPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lfs prune failed: %w", err)
+	}
+	pointers, err := lfsEnumeratePointers(repoPath, patterns)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lfs prune failed: %w", err)
+	}
+
+	keep := make(map[string]bool, len(pointers))
+	for _, p := range pointers {
+		keep[p.OID] = true
+	}
+
+	removed, err := lfs.Prune(lfsCacheDir(repoPath), keep)
 	if err != nil {
-		return nil, nil, fmt.Errorf("git push failed: %w\nOutput: %s", err, string(output))
+		return nil, nil, fmt.Errorf("lfs prune failed: %w", err)
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Pushed changes to remote:\n%s", string(output))},
+			&mcp.TextContent{Text: fmt.Sprintf("Pruned %d unreferenced LFS object(s) from %s", removed, lfsCacheDir(repoPath))},
 		},
 	}, nil, nil
 }
 
+// lfsCacheDir is where fetched LFS objects are cached, named by oid.
+func lfsCacheDir(repoPath string) string {
+	return filepath.Join(repoPath, ".git", "lfs", "objects")
+}
+
+// lfsServerURL derives the repo's LFS batch API endpoint from its origin
+// remote, following the convention <remote>.git/info/lfs.
+func lfsServerURL(ctx context.Context, repoPath string) (string, error) {
+	remote, err := gitbackend.RemoteURL(repoPath, "origin")
+	if err != nil {
+		return "", fmt.Errorf("failed to determine origin remote: %w", err)
+	}
+	remote = strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+	return remote + ".git/info/lfs", nil
+}
+
+// lfsEnumeratePointers walks the working tree for files matching patterns
+// and parses them as LFS pointer files, skipping any that are not (e.g.
+// already materialized).
+func lfsEnumeratePointers(repoPath string, patterns []string) ([]lfs.Pointer, error) {
+	var pointers []lfs.Pointer
+	err := filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !lfs.MatchesAnyPattern(patterns, path) {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		p, err := lfs.ParsePointer(content)
+		if err != nil {
+			return nil
+		}
+		pointers = append(pointers, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan working tree for lfs pointers: %w", err)
+	}
+	return pointers, nil
+}
+
+// lfsFetchAll fetches every LFS object referenced by pointer files in the
+// repo (optionally restricted to include patterns) into the local cache and
+// materializes them over their pointer files in the working tree.
+func lfsFetchAll(ctx context.Context, repoPath string, include []string) (int, error) {
+	tracked, err := lfs.TrackedPatterns(repoPath)
+	if err != nil {
+		return 0, err
+	}
+	patterns := tracked
+	if len(include) > 0 {
+		patterns = include
+	}
+
+	pointers, err := lfsEnumeratePointers(repoPath, patterns)
+	if err != nil {
+		return 0, err
+	}
+	if len(pointers) == 0 {
+		return 0, nil
+	}
+
+	lfsServer, err := lfsServerURL(ctx, repoPath)
+	if err != nil {
+		return 0, err
+	}
+
+	client := lfs.NewClient(os.Getenv("GITHUB_TOKEN"))
+	cacheDir := lfsCacheDir(repoPath)
+	if err := client.Fetch(ctx, lfsServer, cacheDir, pointers); err != nil {
+		return 0, err
+	}
+
+	if err := lfsMaterialize(repoPath, patterns, cacheDir); err != nil {
+		return 0, err
+	}
+
+	return len(pointers), nil
+}
+
+// lfsMaterialize replaces pointer files matching patterns with their real
+// object content from cacheDir.
+func lfsMaterialize(repoPath string, patterns []string, cacheDir string) error {
+	return filepath.Walk(repoPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !lfs.MatchesAnyPattern(patterns, path) {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		p, err := lfs.ParsePointer(content)
+		if err != nil {
+			return nil
+		}
+		object, err := os.ReadFile(filepath.Join(cacheDir, p.OID))
+		if err != nil {
+			return fmt.Errorf("lfs object %s not in cache: %w", p.OID, err)
+		}
+		return os.WriteFile(path, object, info.Mode())
+	})
+}
+
+// lfsUploadAll uploads every object in the repo's LFS cache whose content
+// hashes to its file name (whether written by handleFsWrite or fetched by
+// lfsFetchAll); the batch API skips any the server already has.
+func lfsUploadAll(ctx context.Context, repoPath string) error {
+	patterns, err := lfs.TrackedPatterns(repoPath)
+	if err != nil {
+		return err
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	lfsServer, err := lfsServerURL(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+
+	cacheDir := lfsCacheDir(repoPath)
+	entries, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list lfs cache: %w", err)
+	}
+
+	client := lfs.NewClient(os.Getenv("GITHUB_TOKEN"))
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		localPath := filepath.Join(cacheDir, e.Name())
+		content, err := os.ReadFile(localPath)
+		if err != nil {
+			return err
+		}
+		p := lfs.HashObject(content)
+		if p.OID != e.Name() {
+			continue
+		}
+		if err := client.Upload(ctx, lfsServer, lfs.Pointer{OID: p.OID, Size: info.Size()}, localPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func handleFsRead(ctx context.Context, req *mcp.CallToolRequest, params *FsReadParams) (*mcp.CallToolResult, any, error) {
 	resolved := resolvePath(params.Path)
 	if !isAllowedPath(resolved) {
@@ -291,14 +888,36 @@ func handleFsRead(ctx context.Context, req *mcp.CallToolRequest, params *FsReadP
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	addBytesRead(ctx, len(content))
+
+	if store != nil && int64(len(content)) > *blobThreshold {
+		key := strings.TrimPrefix(resolved, "/")
+		url, err := store.Upload(ctx, key, bytes.NewReader(content))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to upload large file to blob storage: %w", err)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("File %s (%d bytes) exceeds inline threshold; uploaded to blob storage", resolved, len(content))},
+			},
+			Meta: map[string]any{
+				"path":    resolved,
+				"size":    len(content),
+				"blobUrl": url,
+				"blobKey": key,
+				"inlined": false,
+			},
+		}, nil, nil
+	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
 			&mcp.TextContent{Text: string(content)},
 		},
 		Meta: map[string]any{
-			"path": resolved,
-			"size": len(content),
+			"path":    resolved,
+			"size":    len(content),
+			"inlined": true,
 		},
 	}, nil, nil
 }
@@ -314,13 +933,54 @@ func handleFsWrite(ctx context.Context, req *mcp.CallToolRequest, params *FsWrit
 		return nil, nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	if err := os.WriteFile(resolved, []byte(params.Content), 0644); err != nil {
+	content := []byte(params.Content)
+	if params.BlobRef != nil {
+		if store == nil {
+			return nil, nil, fmt.Errorf("blobRef provided but no --storage-addr is configured")
+		}
+		r, err := store.Download(ctx, params.BlobRef.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch blobRef: %w", err)
+		}
+		defer r.Close()
+		content, err = io.ReadAll(r)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read blobRef contents: %w", err)
+		}
+	}
+	addBytesWritten(ctx, len(content))
+
+	root := repoRoot(resolved)
+	patterns, err := lfs.TrackedPatterns(root)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check lfs attributes: %w", err)
+	}
+	if lfs.MatchesAnyPattern(patterns, resolved) {
+		p := lfs.HashObject(content)
+		cacheDir := lfsCacheDir(root)
+		if err := os.MkdirAll(cacheDir, 0755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create lfs cache dir: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(cacheDir, p.OID), content, 0644); err != nil {
+			return nil, nil, fmt.Errorf("failed to cache lfs object: %w", err)
+		}
+		if err := os.WriteFile(resolved, lfs.WritePointer(p.OID, p.Size), 0644); err != nil {
+			return nil, nil, fmt.Errorf("failed to write lfs pointer file: %w", err)
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: fmt.Sprintf("Wrote lfs pointer for %s (%d bytes, oid %s)", resolved, p.Size, p.OID)},
+			},
+		}, nil, nil
+	}
+
+	if err := os.WriteFile(resolved, content, 0644); err != nil {
 		return nil, nil, fmt.Errorf("failed to write file: %w", err)
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: fmt.Sprintf("Successfully wrote %d bytes to %s", len(params.Content), resolved)},
+			&mcp.TextContent{Text: fmt.Sprintf("Successfully wrote %d bytes to %s", len(content), resolved)},
 		},
 	}, nil, nil
 }
@@ -416,29 +1076,116 @@ func handleExecRun(ctx context.Context, req *mcp.CallToolRequest, params *ExecRu
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Millisecond)
 	defer cancel()
 
-	logMsg("info", fmt.Sprintf("Executing: %s in %s", params.Command, workingDir))
+	logMsg(ctx, "info", fmt.Sprintf("Executing: %s in %s", params.Command, workingDir), "command", params.Command, "cwd", workingDir)
 
 	cmd := exec.CommandContext(ctx, "sh", "-c", params.Command)
 	cmd.Dir = workingDir
 	cmd.Env = os.Environ()
 
-	output, err := cmd.CombinedOutput()
+	maxBuffer := params.MaxBufferBytes
+	if maxBuffer <= 0 {
+		maxBuffer = 4 << 20 // 4 MiB
+	}
+	heartbeat := time.Duration(params.HeartbeatMs) * time.Millisecond
+	if heartbeat <= 0 {
+		heartbeat = 5 * time.Second
+	}
+
+	ring := streamio.NewRingBuffer(maxBuffer)
+	var dest io.Writer = ring
+	var notifier *streamio.LineWriter
+	if params.Stream {
+		notifier = newProgressNotifier(ctx, req)
+		dest = io.MultiWriter(ring, notifier)
+	}
+
+	err := runStreamed(cmd, dest, heartbeat, notifier)
+	if notifier != nil {
+		notifier.Flush()
+	}
+
+	output := ring.Bytes()
+	addBytesRead(ctx, len(output))
+	meta := map[string]any{}
+	text := string(output)
+	if store != nil && int64(len(output)) > *blobThreshold {
+		key := fmt.Sprintf("exec-output/%d.log", time.Now().UnixNano())
+		url, uploadErr := store.Upload(ctx, key, bytes.NewReader(output))
+		if uploadErr == nil {
+			text = fmt.Sprintf("Output (%d bytes) exceeds inline threshold; uploaded to blob storage", len(output))
+			meta["blobUrl"] = url
+			meta["blobKey"] = key
+		}
+	}
+
 	if err != nil {
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
-				&mcp.TextContent{Text: string(output)},
+				&mcp.TextContent{Text: text},
 			},
+			Meta:    meta,
 			IsError: true,
 		}, nil, nil
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: string(output)},
+			&mcp.TextContent{Text: text},
 		},
+		Meta: meta,
 	}, nil, nil
 }
 
+// runStreamed runs cmd with its combined stdout/stderr copied to dest as it
+// is produced, rather than buffering the whole thing with CombinedOutput.
+// If notifier is non-nil, a heartbeat notification is emitted on the given
+// interval whenever no new line has arrived, so long-idle commands (e.g. a
+// slow network fetch) still show signs of life.
+func runStreamed(cmd *exec.Cmd, dest io.Writer, heartbeat time.Duration, notifier *streamio.LineWriter) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to open stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start command: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); io.Copy(dest, stdout) }()
+	go func() { defer wg.Done(); io.Copy(dest, stderr) }()
+
+	done := make(chan struct{})
+	if notifier != nil {
+		go func() {
+			ticker := time.NewTicker(heartbeat)
+			defer ticker.Stop()
+			lastSeq := notifier.Seq()
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if notifier.Seq() == lastSeq {
+						notifier.Heartbeat()
+					}
+					lastSeq = notifier.Seq()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(done)
+
+	return cmd.Wait()
+}
+
 func handleBridgeHealth(ctx context.Context, req *mcp.CallToolRequest, params *struct{}) (*mcp.CallToolResult, any, error) {
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
@@ -458,19 +1205,146 @@ func handleBridgeLogs(ctx context.Context, req *mcp.CallToolRequest, params *Bri
 		lines = 100
 	}
 
-	start := len(logBuffer) - lines
+	minLevel := 0
+	if params.Level != "" {
+		lvl, ok := logLevels[params.Level]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown level %q (want debug, info, warn, or error)", params.Level)
+		}
+		minLevel = lvl
+	}
+
+	var since time.Time
+	if params.Since != "" {
+		t, err := time.Parse(time.RFC3339, params.Since)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid since timestamp: %w", err)
+		}
+		since = t
+	}
+
+	var grepRe *regexp.Regexp
+	if params.Grep != "" {
+		if re, err := regexp.Compile(params.Grep); err == nil {
+			grepRe = re
+		}
+	}
+
+	logMu.Lock()
+	all := make([]LogEntry, len(logBuffer))
+	copy(all, logBuffer)
+	logMu.Unlock()
+
+	var filtered []LogEntry
+	for _, entry := range all {
+		if logLevels[entry.Level] < minLevel {
+			continue
+		}
+		if params.Tool != "" && entry.ToolName != params.Tool {
+			continue
+		}
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
+		if params.Grep != "" && !matchesGrep(entry, params.Grep, grepRe) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	start := len(filtered) - lines
 	if start < 0 {
 		start = 0
 	}
+	filtered = filtered[start:]
 
-	var result strings.Builder
-	for _, entry := range logBuffer[start:] {
-		result.WriteString(fmt.Sprintf("[%s] %s: %s\n", entry.Timestamp, entry.Level, entry.Message))
+	var text strings.Builder
+	for _, entry := range filtered {
+		text.WriteString(fmt.Sprintf("[%s] %s tool=%s req=%s: %s %v\n",
+			entry.Timestamp.Format(time.RFC3339), entry.Level, entry.ToolName, entry.RequestID, entry.Message, entry.Fields))
+	}
+
+	encoded, err := json.Marshal(filtered)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode log entries: %w", err)
 	}
 
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: result.String()},
+			&mcp.TextContent{Text: text.String()},
+		},
+		Meta: map[string]any{
+			"entries": json.RawMessage(encoded),
+		},
+	}, nil, nil
+}
+
+// matchesGrep reports whether entry's message or fields match pattern,
+// using re if it compiled or a plain substring match otherwise.
+func matchesGrep(entry LogEntry, pattern string, re *regexp.Regexp) bool {
+	haystack := entry.Message
+	if len(entry.Fields) > 0 {
+		if encoded, err := json.Marshal(entry.Fields); err == nil {
+			haystack += " " + string(encoded)
+		}
+	}
+	if re != nil {
+		return re.MatchString(haystack)
+	}
+	return strings.Contains(haystack, pattern)
+}
+
+func handleBridgeMetrics(ctx context.Context, req *mcp.CallToolRequest, params *ToolMetricsParams) (*mcp.CallToolResult, any, error) {
+	metricsMu.Lock()
+	names := make([]string, 0, len(metricsByTool))
+	for name := range metricsByTool {
+		names = append(names, name)
+	}
+	metricsMu.Unlock()
+	sort.Strings(names)
+
+	type toolReport struct {
+		Tool         string `json:"tool"`
+		Invocations  int64  `json:"invocations"`
+		Errors       int64  `json:"errors"`
+		P50Ms        int64  `json:"p50Ms"`
+		P95Ms        int64  `json:"p95Ms"`
+		BytesRead    int64  `json:"bytesRead"`
+		BytesWritten int64  `json:"bytesWritten"`
+	}
+
+	var text strings.Builder
+	reports := make([]toolReport, 0, len(names))
+	for _, name := range names {
+		m := metricsFor(name)
+		m.mu.Lock()
+		report := toolReport{
+			Tool:         name,
+			Invocations:  m.invocations,
+			Errors:       m.errors,
+			P50Ms:        m.percentile(50).Milliseconds(),
+			P95Ms:        m.percentile(95).Milliseconds(),
+			BytesRead:    m.bytesRead,
+			BytesWritten: m.bytesWritten,
+		}
+		m.mu.Unlock()
+		reports = append(reports, report)
+
+		text.WriteString(fmt.Sprintf("%s: %d calls, %d errors, p50=%dms, p95=%dms, read=%d bytes, written=%d bytes\n",
+			report.Tool, report.Invocations, report.Errors, report.P50Ms, report.P95Ms, report.BytesRead, report.BytesWritten))
+	}
+
+	encoded, err := json.Marshal(reports)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode metrics: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: text.String()},
+		},
+		Meta: map[string]any{
+			"tools": json.RawMessage(encoded),
 		},
 	}, nil, nil
 }